@@ -24,6 +24,7 @@ import (
 	"encoding"
 	"fmt"
 	"math/big"
+	"regexp"
 	"strings"
 )
 
@@ -53,6 +54,8 @@ type ExecutionSegment struct {
 // Ensure we implement those interfaces
 var _ encoding.TextUnmarshaler = &ExecutionSegment{}
 var _ fmt.Stringer = &ExecutionSegment{}
+var _ encoding.TextUnmarshaler = &ExecutionSegmentSequence{}
+var _ fmt.Stringer = &ExecutionSegmentSequence{}
 
 // Helpful "constants" so we don't initialize them in every function call
 var zeroRat, oneRat = big.NewRat(0, 1), big.NewRat(1, 1) //nolint:gochecknoglobals
@@ -95,6 +98,38 @@ func stringToRat(s string) (*big.Rat, error) {
 	return rat, nil
 }
 
+// decimalRegexp matches a plain decimal number, optionally negative, with an
+// optional fractional part - and nothing else. In particular, it rejects the
+// fraction ("1/2"), scientific ("1e-9"), and hexadecimal float ("0x1p-9")
+// syntaxes that big.Rat.SetString also understands, since none of those are
+// decimals and all of them can be used to sneak an arbitrarily large
+// denominator past decimalStringToRat's precision cap below.
+var decimalRegexp = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`) //nolint:gochecknoglobals
+
+// decimalStringToRat converts a decimal string (e.g. "0.456") to a rational
+// number, but rejects values whose fractional part has more than precision
+// digits. Without this cap, a user-supplied string like "0.000...0001" with an
+// arbitrarily long fractional part would make big.Rat construct a huge
+// denominator, which is a cheap way to make k6 itself burn memory and CPU -
+// the same class of problem cosmos-sdk ran into with user-controlled decimal
+// input and fixed by capping the precision in NewRatFromDecimal.
+func decimalStringToRat(decStr string, precision int) (*big.Rat, error) {
+	if !decimalRegexp.MatchString(decStr) {
+		return nil, fmt.Errorf("'%s' is not a valid decimal value", decStr)
+	}
+	parts := strings.SplitN(decStr, ".", 2)
+	if len(parts) == 2 && len(parts[1]) > precision {
+		return nil, fmt.Errorf(
+			"'%s' has more than the maximum allowed %d digits after the decimal point", decStr, precision,
+		)
+	}
+	rat, ok := new(big.Rat).SetString(decStr)
+	if !ok {
+		return nil, fmt.Errorf("'%s' is not a valid decimal value", decStr)
+	}
+	return rat, nil
+}
+
 // UnmarshalText implements the encoding.TextUnmarshaler interface, so that
 // execution segments can be specified as CLI flags, environment variables, and
 // JSON strings.
@@ -192,7 +227,35 @@ func (es *ExecutionSegment) Split(numParts int64) ([]*ExecutionSegment, error) {
 	return results, nil
 }
 
-//TODO: add a NewFromString() method
+// NewExecutionSegmentFromString validates the supplied string and returns the
+// newly created ExecutionSegment or an error. It uses the same parsing logic
+// as UnmarshalText - percentages, decimals and fractions are accepted, both
+// as a `from:to` segment and as a single value shorthand for (0, value].
+func NewExecutionSegmentFromString(toStr string) (*ExecutionSegment, error) {
+	segment := &ExecutionSegment{}
+	if err := segment.UnmarshalText([]byte(toStr)); err != nil {
+		return nil, err
+	}
+	return segment, nil
+}
+
+// NewExecutionSegmentFromDecimal constructs a new ExecutionSegment out of a
+// decimal string (e.g. "0.25"), treating it as the end of a (0, decStr]
+// segment, same as a single-value argument to NewExecutionSegmentFromString.
+//
+// Unlike the general parsing functions above, it caps the number of digits
+// allowed after the decimal point to precision, inspired by cosmos-sdk's
+// NewRatFromDecimal(str, prec). Without such a cap, a maliciously crafted
+// segment string with a huge number of decimal digits could be used to make
+// big.Rat allocate an enormous denominator, so we reject those with a clear
+// error instead of silently paying that cost.
+func NewExecutionSegmentFromDecimal(decStr string, precision int) (*ExecutionSegment, error) {
+	to, err := decimalStringToRat(decStr, precision)
+	if err != nil {
+		return nil, err
+	}
+	return NewExecutionSegment(zeroRat, to)
+}
 
 // Equal returns true only if the two execution segments have the same from and
 // to values.
@@ -289,4 +352,177 @@ func (es *ExecutionSegment) CopyScaleRat(value *big.Rat) *big.Rat {
 		return value
 	}
 	return new(big.Rat).Mul(value, es.length)
+}
+
+// ExecutionSegmentSequence represents a whole execution, split into
+// consecutive execution segments. Crucially, the sum of the lengths of all of
+// the segments in the sequence is exactly 1, i.e. the sequence represents the
+// whole (0, 1] interval without any gaps or overlaps between its segments.
+//
+// Unlike a lone ExecutionSegment, which only knows about its own boundaries,
+// an ExecutionSegmentSequence lets every k6 instance in a distributed run
+// declare the same full partition and then simply pick its own index in it -
+// there's no need for a master node to hand out segments, and no risk of two
+// independently-declared segments silently under- or over-counting VUs at
+// their shared boundary.
+type ExecutionSegmentSequence []*ExecutionSegment
+
+// NewExecutionSegmentSequence validates that the given segments are a
+// contiguous, non-overlapping partition of (0, 1] - the first segment should
+// start at 0, the last one should end at 1, and the end of every other
+// segment should be exactly the start of the next one - and returns them as
+// an ExecutionSegmentSequence if so.
+func NewExecutionSegmentSequence(segments ...*ExecutionSegment) (ExecutionSegmentSequence, error) {
+	ess := ExecutionSegmentSequence(segments)
+	if err := ess.validate(); err != nil {
+		return nil, err
+	}
+	return ess, nil
+}
+
+// validate checks that the sequence is a valid, contiguous partition of
+// (0, 1], as described in NewExecutionSegmentSequence.
+func (ess ExecutionSegmentSequence) validate() error {
+	if len(ess) == 0 {
+		return fmt.Errorf("an execution segment sequence must contain at least one segment")
+	}
+	if ess[0].from.Cmp(zeroRat) != 0 {
+		return fmt.Errorf("the first segment should start at 0, not %s", ess[0].from.RatString())
+	}
+	for i := 0; i < len(ess)-1; i++ {
+		if ess[i].to.Cmp(ess[i+1].from) != 0 {
+			return fmt.Errorf(
+				"the end of segment %d (%s) should be equal to the start of segment %d (%s)",
+				i, ess[i].to.RatString(), i+1, ess[i+1].from.RatString(),
+			)
+		}
+	}
+	if last := ess[len(ess)-1].to; last.Cmp(oneRat) != 0 {
+		return fmt.Errorf("the last segment should end at 1, not %s", last.RatString())
+	}
+	return nil
+}
+
+// String implements the fmt.Stringer interface and returns the canonical
+// comma-separated representation of the sequence, e.g. "0:1/3,1/3:2/3,2/3:1".
+func (ess ExecutionSegmentSequence) String() string {
+	result := make([]string, len(ess))
+	for i, segment := range ess {
+		result[i] = segment.String()
+	}
+	return strings.Join(result, ",")
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, so that
+// execution segment sequences can be specified as CLI flags, environment
+// variables, and JSON strings, in the comma-separated form produced by String().
+func (ess *ExecutionSegmentSequence) UnmarshalText(text []byte) error {
+	items := strings.Split(string(text), ",")
+	segments := make([]*ExecutionSegment, len(items))
+	for i, item := range items {
+		segment := &ExecutionSegment{}
+		if err := segment.UnmarshalText([]byte(item)); err != nil {
+			return fmt.Errorf("invalid segment number %d (%s): %w", i, item, err)
+		}
+		segments[i] = segment
+	}
+
+	result, err := NewExecutionSegmentSequence(segments...)
+	if err != nil {
+		return err
+	}
+	*ess = result
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, so is used for
+// text and JSON encoding of the execution segment sequence.
+func (ess ExecutionSegmentSequence) MarshalText() ([]byte, error) {
+	if len(ess) == 0 {
+		return nil, nil
+	}
+	return []byte(ess.String()), nil
+}
+
+// ScaleInt64 scales the supplied value for the segment at the given index in
+// the sequence. Because NewExecutionSegmentSequence guarantees that the
+// segments are an exact, gapless partition of (0, 1], scaling every index of
+// a sequence and summing the results always gives back the original value,
+// with no rounding drift at the segment boundaries.
+func (ess ExecutionSegmentSequence) ScaleInt64(value int64, index int) int64 {
+	return ess[index].Scale(value)
+}
+
+// lcd returns the lowest common denominator of the (reduced) boundaries of
+// all of the segments in the sequence.
+func (ess ExecutionSegmentSequence) lcd() int64 {
+	result := oneBigInt
+	for _, segment := range ess {
+		denom := segment.to.Denom()
+		gcd := new(big.Int).GCD(nil, nil, result, denom)
+		result = new(big.Int).Div(new(big.Int).Mul(result, denom), gcd)
+	}
+	return result.Int64()
+}
+
+// GetStripedOffsets returns, for every segment in the sequence, the first
+// global VU index (out of the returned lcd, the lowest common denominator of
+// the sequence's boundaries) that's striped to it, i.e. assigned to it under
+// an interleaved, rather than a contiguous block, VU distribution.
+//
+// Concretely, slot i (for i in [0, lcd)) is striped to whichever segment's
+// Scale-d count first grows when going from a hypothetical total of i to a
+// hypothetical total of i+1 - since the segments are a gapless partition,
+// exactly one of them owns every such slot, and since Scale(value) is
+// additively periodic with period lcd, the pattern found in [0, lcd) repeats
+// forever and NewStripedIterator can use it to walk a segment's assigned
+// indices past lcd too.
+//
+// Striping exists because a plain contiguous Scale(value) assignment makes
+// segments later in the sequence only start getting VUs once a ramp-up
+// reaches their share of the total - e.g. with three equal segments, the
+// last one wouldn't get any work until the ramp is two thirds done. Striping
+// instead gives every segment a share of the work from the very first VU.
+func (ess ExecutionSegmentSequence) GetStripedOffsets() (offsets []int64, lcd int64) {
+	lcd = ess.lcd()
+	offsets = make([]int64, len(ess))
+	for j, segment := range ess {
+		for i := int64(0); i < lcd; i++ {
+			if segment.Scale(i+1) != segment.Scale(i) {
+				offsets[j] = i
+				break
+			}
+		}
+	}
+	return offsets, lcd
+}
+
+// ExecutionSegmentStripedIterator lets a single segment in a sequence step
+// through the global VU indices that are striped to it, in increasing order,
+// for as long as needed - see GetStripedOffsets for how the assignment works.
+type ExecutionSegmentStripedIterator struct {
+	segment *ExecutionSegment
+	lcd     int64
+	next    int64
+}
+
+// NewStripedIterator returns an ExecutionSegmentStripedIterator for the
+// segment at the given index in the sequence.
+func (ess ExecutionSegmentSequence) NewStripedIterator(index int) *ExecutionSegmentStripedIterator {
+	offsets, lcd := ess.GetStripedOffsets()
+	return &ExecutionSegmentStripedIterator{
+		segment: ess[index],
+		lcd:     lcd,
+		next:    offsets[index],
+	}
+}
+
+// Next returns the next global VU index striped to this iterator's segment.
+func (it *ExecutionSegmentStripedIterator) Next() int64 {
+	for it.segment.Scale(it.next+1) == it.segment.Scale(it.next) {
+		it.next++
+	}
+	result := it.next
+	it.next++
+	return result
 }
\ No newline at end of file
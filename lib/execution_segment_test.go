@@ -0,0 +1,231 @@
+/*
+ *
+ * k6 - a next-generation load testing tool
+ * Copyright (C) 2019 Load Impact
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as
+ * published by the Free Software Foundation, either version 3 of the
+ * License, or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package lib
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewExecutionSegmentFromString(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		str            string
+		expFrom, expTo string
+	}{
+		{"1/3", "0", "1/3"},
+		{"0.25", "0", "1/4"},
+		{"20%", "0", "1/5"},
+		{"1/2:3/4", "1/2", "3/4"},
+		{"0.5:0.75", "1/2", "3/4"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.str, func(t *testing.T) {
+			t.Parallel()
+			segment, err := NewExecutionSegmentFromString(tc.str)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if segment.from.RatString() != tc.expFrom || segment.to.RatString() != tc.expTo {
+				t.Errorf("expected (%s:%s), got (%s:%s)",
+					tc.expFrom, tc.expTo, segment.from.RatString(), segment.to.RatString())
+			}
+		})
+	}
+
+	t.Run("invalid input returns a nil segment", func(t *testing.T) {
+		t.Parallel()
+		segment, err := NewExecutionSegmentFromString("not a valid segment")
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if segment != nil {
+			t.Fatalf("expected a nil segment, got %#v", segment)
+		}
+	})
+}
+
+func TestNewExecutionSegmentFromDecimal(t *testing.T) {
+	t.Parallel()
+
+	segment, err := NewExecutionSegmentFromDecimal("0.25", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if segment.to.RatString() != "1/4" {
+		t.Errorf("expected to be 1/4, got %s", segment.to.RatString())
+	}
+
+	if _, err := NewExecutionSegmentFromDecimal("0.256", 2); err == nil {
+		t.Fatal("expected an error for a decimal with too many digits after the point")
+	}
+
+	// A crafted "decimal" that's actually fraction syntax shouldn't be able to
+	// sneak a huge denominator past the precision cap just because it has no '.'.
+	hugeFraction := "1/1" + strings.Repeat("0", 200)
+	if _, err := NewExecutionSegmentFromDecimal(hugeFraction, 2); err == nil {
+		t.Fatal("expected fraction syntax to be rejected by NewExecutionSegmentFromDecimal")
+	}
+
+	// Nor should scientific or hexadecimal float syntax, which big.Rat.SetString
+	// also understands and which contain neither '.' nor '/'.
+	for _, huge := range []string{"1e-900000", "0x1p-900000"} {
+		if _, err := NewExecutionSegmentFromDecimal(huge, 2); err == nil {
+			t.Errorf("expected %q to be rejected by NewExecutionSegmentFromDecimal", huge)
+		}
+	}
+}
+
+func TestExecutionSegmentSequenceValidation(t *testing.T) {
+	t.Parallel()
+
+	thirds := mustNewSegments(t, "0:1/3", "1/3:2/3", "2/3:1")
+	if _, err := NewExecutionSegmentSequence(thirds...); err != nil {
+		t.Fatalf("unexpected error for a valid sequence: %s", err)
+	}
+
+	gap := mustNewSegments(t, "0:1/3", "1/2:1")
+	if _, err := NewExecutionSegmentSequence(gap...); err == nil {
+		t.Fatal("expected an error for a sequence with a gap")
+	}
+
+	notFromZero := mustNewSegments(t, "1/3:2/3", "2/3:1")
+	if _, err := NewExecutionSegmentSequence(notFromZero...); err == nil {
+		t.Fatal("expected an error for a sequence that doesn't start at 0")
+	}
+
+	notToOne := mustNewSegments(t, "0:1/3", "1/3:2/3")
+	if _, err := NewExecutionSegmentSequence(notToOne...); err == nil {
+		t.Fatal("expected an error for a sequence that doesn't end at 1")
+	}
+}
+
+func TestExecutionSegmentSequenceTextRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	str := "0:1/3,1/3:2/3,2/3:1"
+	var seq ExecutionSegmentSequence
+	if err := seq.UnmarshalText([]byte(str)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(seq) != 3 {
+		t.Fatalf("expected 3 segments, got %d", len(seq))
+	}
+
+	marshaled, err := seq.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(marshaled) != str {
+		t.Errorf("expected %q, got %q", str, marshaled)
+	}
+
+	if _, err := NewExecutionSegmentSequence(); err == nil {
+		t.Fatal("expected an error for an empty sequence")
+	}
+}
+
+var stripingTestSequences = [][]string{ //nolint:gochecknoglobals
+	{"0:1"},
+	{"0:1/3", "1/3:2/3", "2/3:1"},
+	{"0:1/2", "1/2:3/4", "3/4:1"},
+	{"0:1/5", "1/5:2/5", "2/5:4/5", "4/5:1"},
+}
+
+var stripingTestValues = []int64{0, 1, 2, 3, 7, 13, 60, 100} //nolint:gochecknoglobals
+
+// TestExecutionSegmentSequenceScaleInt64NoDrift proves that scaling a value
+// across every index of a sequence always sums back up to the original value,
+// with no rounding drift at the segment boundaries.
+func TestExecutionSegmentSequenceScaleInt64NoDrift(t *testing.T) {
+	t.Parallel()
+
+	for _, strs := range stripingTestSequences {
+		seq, err := NewExecutionSegmentSequence(mustNewSegments(t, strs...)...)
+		if err != nil {
+			t.Fatalf("unexpected error building %v: %s", strs, err)
+		}
+		for _, value := range stripingTestValues {
+			var sum int64
+			for i := range seq {
+				sum += seq.ScaleInt64(value, i)
+			}
+			if sum != value {
+				t.Errorf("sequence %v, value %d: expected sum %d, got %d", strs, value, value, sum)
+			}
+		}
+	}
+}
+
+// TestExecutionSegmentSequenceGetStripedOffsets proves that, for any value and
+// any valid sequence, the union of every segment's striped indices is exactly
+// {0, ..., value-1}, and that each segment gets exactly as many of them as
+// Scale(value) says it should.
+func TestExecutionSegmentSequenceGetStripedOffsets(t *testing.T) {
+	t.Parallel()
+
+	for _, strs := range stripingTestSequences {
+		seq, err := NewExecutionSegmentSequence(mustNewSegments(t, strs...)...)
+		if err != nil {
+			t.Fatalf("unexpected error building %v: %s", strs, err)
+		}
+
+		for _, value := range stripingTestValues {
+			seen := make(map[int64]int, value)
+			for i := range seq {
+				count := seq.ScaleInt64(value, i)
+				it := seq.NewStripedIterator(i)
+				for n := int64(0); n < count; n++ {
+					idx := it.Next()
+					if idx < 0 || idx >= value {
+						t.Fatalf("sequence %v, value %d, segment %d: out of range index %d", strs, value, i, idx)
+					}
+					seen[idx]++
+				}
+			}
+
+			if len(seen) != int(value) {
+				t.Fatalf("sequence %v, value %d: expected %d distinct indices, got %d", strs, value, value, len(seen))
+			}
+			for idx, count := range seen {
+				if count != 1 {
+					t.Errorf("sequence %v, value %d: index %d assigned to %d segments", strs, value, idx, count)
+				}
+			}
+		}
+	}
+}
+
+func mustNewSegments(t *testing.T, strs ...string) []*ExecutionSegment {
+	t.Helper()
+	segments := make([]*ExecutionSegment, len(strs))
+	for i, str := range strs {
+		segment, err := NewExecutionSegmentFromString(str)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", str, err)
+		}
+		segments[i] = segment
+	}
+	return segments
+}
@@ -19,7 +19,8 @@ import (
 type TriggerParams struct {
 	FieldID cdp.BackendNodeID `json:"fieldId"`                    // Identifies a field that serves as an anchor for autofill.
 	FrameID cdp.FrameID       `json:"frameId,omitempty,omitzero"` // Identifies the frame that field belongs to.
-	Card    *CreditCard       `json:"card"`                       // Credit card information to fill out the form. Credit card data is not saved.
+	Card    *CreditCard       `json:"card,omitempty"`             // Credit card information to fill out the form. Credit card data is not saved.
+	Profile *Address          `json:"profile,omitempty"`          // Address information to fill out the form. Address data is not saved.
 }
 
 // Trigger trigger autofill on a form identified by the fieldId. If the field
@@ -44,6 +45,13 @@ func (p TriggerParams) WithFrameID(frameID cdp.FrameID) *TriggerParams {
 	return &p
 }
 
+// WithProfile address information to fill out the form, in place of credit
+// card information.
+func (p TriggerParams) WithProfile(profile *Address) *TriggerParams {
+	p.Profile = profile
+	return &p
+}
+
 // Do executes Autofill.trigger against the provided context.
 func (p *TriggerParams) Do(ctx context.Context) (err error) {
 	return cdp.Execute(ctx, CommandTrigger, p, nil)
@@ -0,0 +1,22 @@
+package autofill
+
+import (
+	"github.com/chromedp/cdproto/cdp"
+)
+
+// AddressFormFilledParams emitted when an address form is filled.
+type AddressFormFilledParams struct {
+	FilledFields []*FilledField `json:"filledFields"` // Information about the fields that were filled.
+	AddressUI    *AddressUI     `json:"addressUi"`    // An UI representation of the address used to fill the form.
+}
+
+// CreditCardFormFilledParams emitted when a credit card form is filled.
+type CreditCardFormFilledParams struct {
+	FilledFields []*FilledField `json:"filledFields"` // Information about the fields that were filled.
+}
+
+// Event names.
+const (
+	EventAddressFormFilled    cdp.MethodType = "Autofill.addressFormFilled"
+	EventCreditCardFormFilled cdp.MethodType = "Autofill.creditCardFormFilled"
+)
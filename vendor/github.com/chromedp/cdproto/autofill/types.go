@@ -0,0 +1,66 @@
+package autofill
+
+import (
+	"github.com/chromedp/cdproto/cdp"
+)
+
+// CreditCard credit card information.
+type CreditCard struct {
+	Number      string `json:"number"`      // 16-digit credit card number.
+	Name        string `json:"name"`        // Name of the card.
+	ExpiryMonth string `json:"expiryMonth"` // 2-digit expiry month.
+	ExpiryYear  string `json:"expiryYear"`  // 4-digit expiry year.
+	Cvc         string `json:"cvc"`         // 3-digit card verification code.
+}
+
+// AddressField address field.
+type AddressField struct {
+	Name  string `json:"name"`  // Address field name.
+	Value string `json:"value"` // Address field value.
+}
+
+// AddressFields a list of address fields that group together fields that are displayed together in the UI.
+type AddressFields struct {
+	Fields []*AddressField `json:"fields"`
+}
+
+// Address address represented as a list of fields.
+type Address struct {
+	Fields []*AddressField `json:"fields"`
+}
+
+// AddressUI defines how an address can be displayed like in
+// chrome://settings/addresses. It's a two dimensional array, each inner
+// array is an "address information line", and when rendered in a UI
+// surface should be displayed as such.
+type AddressUI struct {
+	AddressFields []*AddressFields `json:"addressFields"`
+}
+
+// FillingStrategy describes the type of autofill value that was filled
+// into a field.
+type FillingStrategy string
+
+// String returns the FillingStrategy as string value.
+func (t FillingStrategy) String() string {
+	return string(t)
+}
+
+// FillingStrategy values.
+const (
+	FillingStrategyAutocompleteAttribute FillingStrategy = "autocompleteAttribute"
+	FillingStrategyAutofillInferred      FillingStrategy = "autofillInferred"
+)
+
+// FilledField describes a single field that Chrome filled out during an
+// autofill, and which value/strategy it used for it.
+type FilledField struct {
+	HTMLType        string            `json:"htmlType"`        // The type of the field, e.g text, password etc.
+	ID              string            `json:"id"`              // The id attribute of the field.
+	Name            string            `json:"name"`            // The name attribute of the field.
+	Value           string            `json:"value"`           // The value that Chrome filled into the field.
+	AutofillType    string            `json:"autofillType"`    // The type of the autofill value, e.g Name, Address, Email etc.
+	FillingStrategy FillingStrategy   `json:"fillingStrategy"` // The filling strategy used for this field.
+	FieldID         cdp.BackendNodeID `json:"fieldId"`         // The id of the field that Chrome filled.
+	FrameID         cdp.FrameID       `json:"frameId"`         // The frame id of the field.
+}
@@ -0,0 +1,46 @@
+// Package cdproto contains the Chrome DevTools Protocol commands, events,
+// and types, split into packages by protocol domain.
+//
+// NOTE(vendoring): this tree only vendors the autofill domain, so only its
+// events are registered here instead of the full, generated set covering
+// every domain.
+package cdproto
+
+import (
+	"encoding/json"
+
+	"github.com/chromedp/cdproto/autofill"
+	"github.com/chromedp/cdproto/cdp"
+)
+
+// eventTypes maps a CDP event's method name to a function returning a fresh,
+// empty value that its JSON payload can be unmarshaled into, so that e.g.
+// page.waitForEvent('autofill.addressFormFilled') can dispatch to the right
+// Go type.
+var eventTypes = map[cdp.MethodType]func() interface{}{ //nolint:gochecknoglobals
+	autofill.EventAddressFormFilled:    func() interface{} { return new(autofill.AddressFormFilledParams) },
+	autofill.EventCreditCardFormFilled: func() interface{} { return new(autofill.CreditCardFormFilledParams) },
+}
+
+// UnmarshalMessage unmarshals a CDP event's JSON payload based on its method
+// type.
+func UnmarshalMessage(methodType cdp.MethodType, data []byte) (interface{}, error) {
+	f, ok := eventTypes[methodType]
+	if !ok {
+		return nil, ErrUnknownCommandOrEvent(methodType)
+	}
+	v := f()
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// ErrUnknownCommandOrEvent is returned when UnmarshalMessage encounters an
+// unknown method type.
+type ErrUnknownCommandOrEvent cdp.MethodType
+
+// Error satisfies the error interface.
+func (e ErrUnknownCommandOrEvent) Error() string {
+	return "unknown command or event " + string(e)
+}